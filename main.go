@@ -2,59 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"runtime"
 	"strings"
-	"sync"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/ec2"
-	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/adesokandijam/aws-sdk-concurrency/pkg/inventory"
 	"gopkg.in/ini.v1"
 )
 
-var regions = []string{
-	"us-east-1",
-	"eu-west-1",
-	"eu-west-2", // added region
-}
-
-func listEC2Instances(region, profile string) error {
-	cfg, err := config.LoadDefaultConfig(
-		context.Background(),
-		config.WithSharedConfigProfile(profile),
-		config.WithRegion(region),
-	)
-	if err != nil {
-		return fmt.Errorf("[%s/%s] config error: %w", profile, region, err)
-	}
-
-	client := ec2.NewFromConfig(cfg)
-	result, err := client.DescribeInstances(context.Background(), &ec2.DescribeInstancesInput{})
-	if err != nil {
-		return fmt.Errorf("[%s/%s] describe error: %w", profile, region, err)
-	}
-
-	count := 0
-	for _, reservation := range result.Reservations {
-		for _, instance := range reservation.Instances {
-			if instance.State.Name == types.InstanceStateNameRunning {
-				count++
-			}
-		}
-	}
-	fmt.Printf("[%s/%s] Running instances: %d\n", profile, region, count)
-	return nil
-}
+const defaultProfileRPS = 10
 
 func getProfiles() ([]string, error) {
-	awsConfigPath := os.Getenv("AWS_CONFIG_FILE")
-	if awsConfigPath == "" {
-		awsConfigPath = os.ExpandEnv("$HOME/.aws/config")
-	}
-	cfgFile, err := ini.Load(awsConfigPath)
+	cfgFile, err := ini.Load(inventory.DefaultConfigPath())
 	if err != nil {
 		return nil, err
 	}
@@ -68,40 +32,130 @@ func getProfiles() ([]string, error) {
 	return profiles, nil
 }
 
-func main() {
+// buildSink picks the OutputSink named by sinkFlag, wiring up whatever
+// flags that sink needs.
+func buildSink(sinkFlag, outFlag, metricsAddrFlag, formatFlag, filterFlag string) (inventory.OutputSink, error) {
+	switch sinkFlag {
+	case "", "stdout":
+		return inventory.StdoutSink{Format: formatFlag, Filter: filterFlag}, nil
+	case "json-file":
+		if outFlag == "" {
+			return nil, fmt.Errorf("-sink=json-file requires -out")
+		}
+		return inventory.JSONFileSink{Path: outFlag}, nil
+	case "ndjson":
+		if outFlag == "" {
+			return nil, fmt.Errorf("-sink=ndjson requires -out")
+		}
+		return inventory.NDJSONSink{Path: outFlag}, nil
+	case "sqlite":
+		if outFlag == "" {
+			return nil, fmt.Errorf("-sink=sqlite requires -out")
+		}
+		return inventory.SQLiteSink{Path: outFlag}, nil
+	case "prometheus":
+		return &inventory.PrometheusSink{Addr: metricsAddrFlag}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink %q (want stdout, json-file, ndjson, sqlite, or prometheus)", sinkFlag)
+	}
+}
+
+func run() error {
+	includeFlag := flag.String("include", "", "comma-separated glob(s) of regions to include, e.g. us-*,eu-west-1")
+	excludeFlag := flag.String("exclude", "", "comma-separated glob(s) of regions to exclude")
+	optInFlag := flag.String("opt-in", "", "restrict to regions with this opt-in status: opt-in-not-required, opted-in")
+	workersFlag := flag.Int("workers", runtime.NumCPU()*4, "size of the (profile, region) worker pool")
+	rpsFlag := flag.Float64("profile-rps", defaultProfileRPS, "max DescribeInstances calls per second, per profile")
+	sinkFlag := flag.String("sink", "stdout", "output sink: stdout, json-file, ndjson, sqlite, or prometheus")
+	outFlag := flag.String("out", "", "output file path for the json-file, ndjson, and sqlite sinks")
+	metricsAddrFlag := flag.String("metrics-addr", ":9090", "listen address for the prometheus sink's /metrics endpoint")
+	formatFlag := flag.String("format", "table", "stdout sink format: table, csv, or json")
+	filterFlag := flag.String("filter", "", "stdout sink: only show instances with a field containing this substring")
+	refreshFlag := flag.Duration("refresh-interval", 5*time.Minute, "prometheus sink: how often to re-scan and refresh the gauges")
+	tuiFlag := flag.Bool("tui", false, "show a live dashboard instead of the sink's output")
+	flag.Parse()
+
+	ctx := context.Background()
 
 	profiles, err := getProfiles()
 	if err != nil {
-		log.Fatalf("Failed to get profiles: %v", err)
+		return fmt.Errorf("failed to get profiles: %w", err)
 	}
-	start := time.Now()
-	// Synchronous version (commented out )
 
-	for _, profile := range profiles {
-		for _, region := range regions {
-			if err := listEC2Instances(region, profile); err != nil {
-				fmt.Fprintln(os.Stderr, err)
-			}
-		}
+	collector, err := inventory.NewCollector(inventory.CollectorConfig{
+		Include:    splitGlobs(*includeFlag),
+		Exclude:    splitGlobs(*excludeFlag),
+		OptIn:      *optInFlag,
+		Workers:    *workersFlag,
+		ProfileRPS: *rpsFlag,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *tuiFlag {
+		return runTUI(ctx, collector, profiles)
 	}
-	fmt.Printf("\nDone in %s\n", time.Since(start))
-	start2 := time.Now()
-
-	// Concurrent version with WaitGroup
-	var wg sync.WaitGroup
-	for _, profile := range profiles {
-		for _, region := range regions {
-			wg.Add(1)
-			go func(p, r string) {
-				defer wg.Done()
-				if err := listEC2Instances(r, p); err != nil {
-					fmt.Fprintln(os.Stderr, err)
-				}
-			}(profile, region)
+
+	sink, err := buildSink(*sinkFlag, *outFlag, *metricsAddrFlag, *formatFlag, *filterFlag)
+	if err != nil {
+		return err
+	}
+
+	if *sinkFlag == "prometheus" {
+		return runExporter(ctx, collector, sink, profiles, *refreshFlag)
+	}
+	return scanOnce(ctx, collector, sink, profiles)
+}
+
+func scanOnce(ctx context.Context, collector *inventory.Collector, sink inventory.OutputSink, profiles []string) error {
+	inv, collectErr := collector.Collect(ctx, profiles)
+
+	var errs []error
+	if collectErr != nil {
+		errs = append(errs, collectErr)
+	}
+	for _, regionErr := range inv.Errors() {
+		errs = append(errs, regionErr)
+	}
+
+	if err := sink.Write(ctx, inv); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// runExporter re-scans on every tick and refreshes the sink's gauges, so
+// the process can sit behind a Prometheus scrape config as a long-lived
+// exporter instead of a one-shot CLI.
+func runExporter(ctx context.Context, collector *inventory.Collector, sink inventory.OutputSink, profiles []string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("-refresh-interval must be positive, got %s", interval)
+	}
+
+	if err := scanOnce(ctx, collector, sink, profiles); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := scanOnce(ctx, collector, sink, profiles); err != nil {
+			fmt.Fprintln(os.Stderr, err)
 		}
 	}
+	return nil
+}
 
-	wg.Wait()
+func splitGlobs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
 
-	fmt.Printf("\nDone in %s\n", time.Since(start2))
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
 }
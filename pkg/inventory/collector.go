@@ -0,0 +1,432 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"golang.org/x/time/rate"
+)
+
+const (
+	bootstrapRegion = "us-east-1"
+
+	defaultProfileBurst = 10
+
+	maxRetries  = 5
+	baseBackoff = 200 * time.Millisecond
+	maxBackoff  = 10 * time.Second
+)
+
+// RegionResolver discovers the regions enabled for an account instead of
+// relying on a hard-coded list, so accounts with a different enabled-region
+// set than the author's don't silently go unscanned.
+type RegionResolver struct {
+	include     []string
+	exclude     []string
+	optInStatus string // "", "opt-in-not-required", or "opted-in"
+}
+
+// NewRegionResolver builds a RegionResolver. include/exclude are glob
+// patterns (matched with path.Match semantics) applied to the region name;
+// optInStatus, when non-empty, restricts results to regions reporting that
+// OptInStatus from DescribeRegions.
+func NewRegionResolver(include, exclude []string, optInStatus string) *RegionResolver {
+	return &RegionResolver{
+		include:     include,
+		exclude:     exclude,
+		optInStatus: optInStatus,
+	}
+}
+
+// Resolve calls DescribeRegions once, against the bootstrap region, and
+// returns every region enabled for the given profile's account after
+// applying the include/exclude globs and opt-in filter.
+func (r *RegionResolver) Resolve(ctx context.Context, profile string, provider aws.CredentialsProvider) ([]string, error) {
+	cfg, err := config.LoadDefaultConfig(
+		ctx,
+		config.WithCredentialsProvider(provider),
+		config.WithRegion(bootstrapRegion),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] config error: %w", profile, err)
+	}
+
+	client := ec2.NewFromConfig(cfg)
+	out, err := client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%s] describe regions error: %w", profile, err)
+	}
+
+	var regions []string
+	for _, region := range out.Regions {
+		name := aws.ToString(region.RegionName)
+
+		if r.optInStatus != "" && aws.ToString(region.OptInStatus) != r.optInStatus {
+			continue
+		}
+		if !r.matches(name) {
+			continue
+		}
+		regions = append(regions, name)
+	}
+	return regions, nil
+}
+
+func (r *RegionResolver) matches(region string) bool {
+	if len(r.include) > 0 && !matchesAny(r.include, region) {
+		return false
+	}
+	if matchesAny(r.exclude, region) {
+		return false
+	}
+	return true
+}
+
+func matchesAny(patterns []string, region string) bool {
+	for _, pattern := range patterns {
+		if ok, err := filepath.Match(pattern, region); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func nameTag(tags []types.Tag) string {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == "Name" {
+			return aws.ToString(tag.Value)
+		}
+	}
+	return ""
+}
+
+func toInstance(profile, region string, in types.Instance) Instance {
+	var launchTime time.Time
+	if in.LaunchTime != nil {
+		launchTime = *in.LaunchTime
+	}
+
+	var az string
+	if in.Placement != nil {
+		az = aws.ToString(in.Placement.AvailabilityZone)
+	}
+
+	var state string
+	if in.State != nil {
+		state = string(in.State.Name)
+	}
+
+	return Instance{
+		ID:         aws.ToString(in.InstanceId),
+		Name:       nameTag(in.Tags),
+		PrivateIP:  aws.ToString(in.PrivateIpAddress),
+		PublicIP:   aws.ToString(in.PublicIpAddress),
+		Type:       string(in.InstanceType),
+		State:      state,
+		VPC:        aws.ToString(in.VpcId),
+		AZ:         az,
+		LaunchTime: launchTime,
+		Profile:    profile,
+		Region:     region,
+	}
+}
+
+// describeAllInstances walks every page of DescribeInstances for a
+// (profile, region) pair, so accounts with more than one page of
+// instances are no longer under-reported.
+func describeAllInstances(ctx context.Context, region, profile string, provider aws.CredentialsProvider) ([]Instance, error) {
+	cfg, err := config.LoadDefaultConfig(
+		ctx,
+		config.WithCredentialsProvider(provider),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("[%s/%s] config error: %w", profile, region, err)
+	}
+
+	client := ec2.NewFromConfig(cfg)
+	paginator := ec2.NewDescribeInstancesPaginator(client, &ec2.DescribeInstancesInput{})
+
+	var instances []Instance
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("[%s/%s] describe error: %w", profile, region, err)
+		}
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				instances = append(instances, toInstance(profile, region, instance))
+			}
+		}
+	}
+	return instances, nil
+}
+
+// isThrottlingError reports whether err is an EC2 API throttling response
+// (RequestLimitExceeded or the more generic Throttling/ThrottlingException
+// codes), as opposed to a hard failure that retrying won't fix.
+func isThrottlingError(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "RequestLimitExceeded", "Throttling", "ThrottlingException":
+		return true
+	default:
+		return false
+	}
+}
+
+// describeAllInstancesWithRetry wraps describeAllInstances with a
+// per-profile token bucket and exponential backoff, so a burst of
+// (profile, region) jobs for the same account doesn't trip EC2 API
+// throttling.
+func describeAllInstancesWithRetry(ctx context.Context, limiter *rate.Limiter, region, profile string, provider aws.CredentialsProvider) ([]Instance, error) {
+	return retryFetch(ctx, limiter, region, profile, func(ctx context.Context) ([]Instance, error) {
+		return describeAllInstances(ctx, region, profile, provider)
+	})
+}
+
+// retryFetch retries fetch under a per-profile token bucket and exponential
+// backoff whenever it fails with a throttling error. Each attempt is
+// independent: a failed attempt's partial result is discarded rather than
+// merged with earlier attempts, so a throttle on a later page never
+// duplicates instances already returned by an earlier one.
+func retryFetch(ctx context.Context, limiter *rate.Limiter, region, profile string, fetch func(ctx context.Context) ([]Instance, error)) ([]Instance, error) {
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		instances, err := fetch(ctx)
+		if err == nil {
+			return instances, nil
+		}
+		lastErr = err
+		if !isThrottlingError(err) {
+			return nil, err
+		}
+
+		backoff := baseBackoff * time.Duration(1<<attempt)
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+		backoff += time.Duration(rand.Int63n(int64(backoff/2 + 1)))
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return nil, fmt.Errorf("[%s/%s] giving up after %d attempts: %w", profile, region, maxRetries+1, lastErr)
+}
+
+// CollectorConfig configures a Collector.
+type CollectorConfig struct {
+	Include    []string
+	Exclude    []string
+	OptIn      string
+	Workers    int
+	ProfileRPS float64
+
+	// ConfigPath is the shared AWS config file to read profiles from.
+	// Defaults to DefaultConfigPath() when empty.
+	ConfigPath string
+}
+
+// Collector discovers regions and instances across a set of profiles,
+// using a bounded worker pool, a per-profile rate limiter, and a shared
+// CredentialResolver so SSO/assume-role profiles authenticate once.
+type Collector struct {
+	resolver   *RegionResolver
+	creds      *CredentialResolver
+	workers    int
+	profileRPS float64
+}
+
+// NewCollector builds a Collector from cfg.
+func NewCollector(cfg CollectorConfig) (*Collector, error) {
+	if cfg.Workers < 1 {
+		return nil, fmt.Errorf("workers must be at least 1, got %d", cfg.Workers)
+	}
+
+	configPath := cfg.ConfigPath
+	if configPath == "" {
+		configPath = DefaultConfigPath()
+	}
+	return &Collector{
+		resolver:   NewRegionResolver(cfg.Include, cfg.Exclude, cfg.OptIn),
+		creds:      NewCredentialResolver(configPath),
+		workers:    cfg.Workers,
+		profileRPS: cfg.ProfileRPS,
+	}, nil
+}
+
+type job struct {
+	profile  string
+	region   string
+	provider aws.CredentialsProvider
+}
+
+type jobResult struct {
+	profile   string
+	region    string
+	instances []Instance
+	err       error
+}
+
+// ProgressStatus is the lifecycle stage of a single (profile, region) job.
+type ProgressStatus int
+
+const (
+	ProgressPending ProgressStatus = iota
+	ProgressRunning
+	ProgressDone
+)
+
+// ProgressEvent reports a (profile, region) job's transition through
+// pending -> running -> done, so a caller (e.g. a TUI) can render live
+// progress as the worker pool drains. Count and Err are only meaningful
+// once Status is ProgressDone.
+type ProgressEvent struct {
+	Profile string
+	Region  string
+	Status  ProgressStatus
+	Count   int
+	Err     error
+}
+
+// sendProgress delivers ev on progress, or drops it once ctx is done, so a
+// caller that stops reading progress (e.g. a cancelled TUI) doesn't wedge
+// the worker goroutine on an unbuffered send forever.
+func sendProgress(ctx context.Context, progress chan<- ProgressEvent, ev ProgressEvent) {
+	if progress == nil {
+		return
+	}
+	select {
+	case progress <- ev:
+	case <-ctx.Done():
+	}
+}
+
+func countRunning(instances []Instance) int {
+	count := 0
+	for _, in := range instances {
+		if in.State == "running" {
+			count++
+		}
+	}
+	return count
+}
+
+// Collect resolves regions for every profile and fans out a bounded pool
+// of workers over the resulting (profile, region) pairs, returning the
+// aggregated Inventory. Per-region failures are recorded on the matching
+// RegionResult rather than aborting the run.
+func (c *Collector) Collect(ctx context.Context, profiles []string) (Inventory, error) {
+	return c.CollectWithProgress(ctx, profiles, nil)
+}
+
+// CollectWithProgress behaves like Collect, additionally emitting a
+// ProgressEvent on progress for every (profile, region) job as it moves
+// from pending to running to done. progress is closed once every job has
+// reported done. Passing a nil channel is equivalent to calling Collect.
+func (c *Collector) CollectWithProgress(ctx context.Context, profiles []string, progress chan<- ProgressEvent) (Inventory, error) {
+	if progress != nil {
+		defer close(progress)
+	}
+
+	limiters := make(map[string]*rate.Limiter, len(profiles))
+	var jobs []job
+	accountsByProfile := make(map[string]*Account, len(profiles))
+
+	var resolveErrs []error
+	for _, profile := range profiles {
+		accountsByProfile[profile] = &Account{Profile: profile}
+
+		provider, err := c.creds.ProviderFor(ctx, profile)
+		if err != nil {
+			resolveErrs = append(resolveErrs, err)
+			continue
+		}
+
+		regions, err := c.resolver.Resolve(ctx, profile, provider)
+		if err != nil {
+			resolveErrs = append(resolveErrs, err)
+			continue
+		}
+		limiters[profile] = rate.NewLimiter(rate.Limit(c.profileRPS), defaultProfileBurst)
+		for _, region := range regions {
+			jobs = append(jobs, job{profile: profile, region: region, provider: provider})
+		}
+	}
+
+	for _, j := range jobs {
+		sendProgress(ctx, progress, ProgressEvent{Profile: j.profile, Region: j.region, Status: ProgressPending})
+	}
+
+	jobCh := make(chan job)
+	resultCh := make(chan jobResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobCh {
+				sendProgress(ctx, progress, ProgressEvent{Profile: j.profile, Region: j.region, Status: ProgressRunning})
+
+				instances, err := describeAllInstancesWithRetry(ctx, limiters[j.profile], j.region, j.profile, j.provider)
+
+				sendProgress(ctx, progress, ProgressEvent{Profile: j.profile, Region: j.region, Status: ProgressDone, Count: countRunning(instances), Err: err})
+				resultCh <- jobResult{profile: j.profile, region: j.region, instances: instances, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for _, j := range jobs {
+			jobCh <- j
+		}
+		close(jobCh)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	for res := range resultCh {
+		account := accountsByProfile[res.profile]
+		account.Regions = append(account.Regions, RegionResult{
+			Region:    res.region,
+			Instances: res.instances,
+			Err:       res.err,
+		})
+	}
+
+	inv := Inventory{Accounts: make([]Account, 0, len(profiles))}
+	for _, profile := range profiles {
+		inv.Accounts = append(inv.Accounts, *accountsByProfile[profile])
+	}
+
+	if len(resolveErrs) > 0 {
+		return inv, errors.Join(resolveErrs...)
+	}
+	return inv, nil
+}
@@ -0,0 +1,80 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRegionResolverMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		region  string
+		want    bool
+	}{
+		{"no filters", nil, nil, "us-east-1", true},
+		{"include match", []string{"us-*"}, nil, "us-east-1", true},
+		{"include no match", []string{"us-*"}, nil, "eu-west-1", false},
+		{"exclude match", nil, []string{"eu-*"}, "eu-west-1", false},
+		{"exclude wins over include", []string{"*"}, []string{"eu-*"}, "eu-west-1", false},
+		{"multiple include globs", []string{"ap-*", "us-*"}, nil, "ap-southeast-2", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegionResolver(tt.include, tt.exclude, "")
+			if got := r.matches(tt.region); got != tt.want {
+				t.Errorf("matches(%q) = %v, want %v", tt.region, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCollectorRejectsNonPositiveWorkers(t *testing.T) {
+	for _, workers := range []int{0, -1} {
+		if _, err := NewCollector(CollectorConfig{Workers: workers, ProfileRPS: 1}); err == nil {
+			t.Errorf("NewCollector(Workers: %d) error = nil, want an error", workers)
+		}
+	}
+
+	if _, err := NewCollector(CollectorConfig{Workers: 1, ProfileRPS: 1}); err != nil {
+		t.Errorf("NewCollector(Workers: 1) error = %v, want nil", err)
+	}
+}
+
+func TestCountRunning(t *testing.T) {
+	instances := []Instance{
+		{ID: "i-1", State: "running"},
+		{ID: "i-2", State: "stopped"},
+		{ID: "i-3", State: "running"},
+	}
+	if got, want := countRunning(instances), 2; got != want {
+		t.Errorf("countRunning() = %d, want %d", got, want)
+	}
+	if got, want := countRunning(nil), 0; got != want {
+		t.Errorf("countRunning(nil) = %d, want %d", got, want)
+	}
+}
+
+// TestSendProgressDropsOnceCancelled guards against the TUI's goroutine
+// leak: once a caller stops reading progress (ctx cancelled), sendProgress
+// must return instead of blocking forever on the unbuffered channel.
+func TestSendProgressDropsOnceCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	progress := make(chan ProgressEvent) // nobody ever reads this
+
+	done := make(chan struct{})
+	go func() {
+		sendProgress(ctx, progress, ProgressEvent{Profile: "p", Region: "r"})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sendProgress did not return after ctx was cancelled")
+	}
+}
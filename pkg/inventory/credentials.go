@@ -0,0 +1,159 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"gopkg.in/ini.v1"
+)
+
+// CredentialResolver builds an aws.CredentialsProvider per profile and
+// caches it, so SSO device-code logins and MFA prompts happen at most
+// once per profile even when that profile is fanned out across many
+// regions concurrently.
+type CredentialResolver struct {
+	configPath string
+
+	mu    sync.Mutex
+	cache map[string]aws.CredentialsProvider
+}
+
+// NewCredentialResolver builds a CredentialResolver reading profiles from
+// configPath (the shared AWS config file, e.g. ~/.aws/config).
+func NewCredentialResolver(configPath string) *CredentialResolver {
+	return &CredentialResolver{
+		configPath: configPath,
+		cache:      make(map[string]aws.CredentialsProvider),
+	}
+}
+
+// ProviderFor returns the cached credentials provider for profile,
+// building and caching one on first use.
+func (r *CredentialResolver) ProviderFor(ctx context.Context, profile string) (aws.CredentialsProvider, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.providerForLocked(ctx, profile, nil)
+}
+
+// providerForLocked resolves profile, recursing into its source_profile
+// chain for role-chained profiles. chain holds every profile already being
+// resolved in the current call stack, so a source_profile cycle is
+// reported as an error instead of recursing forever.
+func (r *CredentialResolver) providerForLocked(ctx context.Context, profile string, chain []string) (aws.CredentialsProvider, error) {
+	if p, ok := r.cache[profile]; ok {
+		return p, nil
+	}
+
+	for _, seen := range chain {
+		if seen == profile {
+			return nil, fmt.Errorf("circular source_profile chain: %s -> %s", strings.Join(chain, " -> "), profile)
+		}
+	}
+	chain = append(chain, profile)
+
+	section, err := r.section(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var provider aws.CredentialsProvider
+	if section.HasKey("role_arn") {
+		provider, err = r.buildAssumeRoleProvider(ctx, profile, section, chain)
+	} else {
+		// Plain shared-config profiles and SSO profiles (sso_session /
+		// sso_start_url) are both resolved by config.LoadDefaultConfig,
+		// which triggers the device-code login on first use and reuses
+		// the token cached under ~/.aws/sso/cache afterwards.
+		provider, err = r.buildSharedConfigProvider(ctx, profile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cached := aws.NewCredentialsCache(provider)
+	r.cache[profile] = cached
+	return cached, nil
+}
+
+func (r *CredentialResolver) buildSharedConfigProvider(ctx context.Context, profile string) (aws.CredentialsProvider, error) {
+	cfg, err := config.LoadDefaultConfig(
+		ctx,
+		config.WithSharedConfigFiles([]string{r.configPath}),
+		config.WithSharedConfigProfile(profile),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] credential resolution error: %w", profile, err)
+	}
+	return cfg.Credentials, nil
+}
+
+// buildAssumeRoleProvider wraps the source profile's (already cached)
+// credentials in an stscreds.AssumeRoleProvider, prompting for an MFA
+// token via stdin when mfa_serial is set.
+func (r *CredentialResolver) buildAssumeRoleProvider(ctx context.Context, profile string, section *ini.Section, chain []string) (aws.CredentialsProvider, error) {
+	sourceProfile := section.Key("source_profile").String()
+	if sourceProfile == "" {
+		return nil, fmt.Errorf("[%s] role_arn set without source_profile", profile)
+	}
+
+	sourceProvider, err := r.providerForLocked(ctx, sourceProfile, chain)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] resolving source_profile %q: %w", profile, sourceProfile, err)
+	}
+
+	cfg, err := config.LoadDefaultConfig(
+		ctx,
+		config.WithSharedConfigFiles([]string{r.configPath}),
+		config.WithRegion(bootstrapRegion),
+		config.WithCredentialsProvider(sourceProvider),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] config error: %w", profile, err)
+	}
+
+	client := sts.NewFromConfig(cfg)
+	roleArn := section.Key("role_arn").String()
+
+	provider := stscreds.NewAssumeRoleProvider(client, roleArn, func(o *stscreds.AssumeRoleOptions) {
+		if mfaSerial := section.Key("mfa_serial").String(); mfaSerial != "" {
+			o.SerialNumber = aws.String(mfaSerial)
+			o.TokenProvider = stscreds.StdinTokenProvider
+		}
+		if externalID := section.Key("external_id").String(); externalID != "" {
+			o.ExternalID = aws.String(externalID)
+		}
+	})
+	return provider, nil
+}
+
+func (r *CredentialResolver) section(profile string) (*ini.Section, error) {
+	cfgFile, err := ini.Load(r.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	name := "profile " + profile
+	if profile == "default" {
+		name = "default"
+	}
+	if !cfgFile.HasSection(name) {
+		return nil, fmt.Errorf("profile %q not found in %s", profile, r.configPath)
+	}
+	return cfgFile.Section(name), nil
+}
+
+// DefaultConfigPath returns $AWS_CONFIG_FILE, falling back to
+// $HOME/.aws/config, matching the AWS CLI's own resolution order.
+func DefaultConfigPath() string {
+	if path := os.Getenv("AWS_CONFIG_FILE"); path != "" {
+		return path
+	}
+	return os.ExpandEnv("$HOME/.aws/config")
+}
@@ -0,0 +1,107 @@
+package inventory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestCredentialResolverSection(t *testing.T) {
+	path := writeConfig(t, `
+[profile plain]
+region = us-east-1
+
+[default]
+region = us-west-2
+`)
+	r := NewCredentialResolver(path)
+
+	section, err := r.section("plain")
+	if err != nil {
+		t.Fatalf("section(%q) error = %v", "plain", err)
+	}
+	if got := section.Key("region").String(); got != "us-east-1" {
+		t.Errorf("section(%q) region = %q, want %q", "plain", got, "us-east-1")
+	}
+
+	if _, err := r.section("default"); err != nil {
+		t.Errorf("section(%q) error = %v, want nil", "default", err)
+	}
+
+	if _, err := r.section("missing"); err == nil {
+		t.Error("section(\"missing\") error = nil, want an error")
+	}
+}
+
+func TestCredentialResolverAssumeRoleRequiresSourceProfile(t *testing.T) {
+	path := writeConfig(t, `
+[profile chained]
+role_arn = arn:aws:iam::111122223333:role/Example
+`)
+	r := NewCredentialResolver(path)
+
+	_, err := r.ProviderFor(context.Background(), "chained")
+	if err == nil {
+		t.Fatal("ProviderFor() error = nil, want an error for role_arn without source_profile")
+	}
+	if !strings.Contains(err.Error(), "source_profile") {
+		t.Errorf("ProviderFor() error = %v, want it to mention source_profile", err)
+	}
+}
+
+func TestCredentialResolverDetectsSourceProfileCycle(t *testing.T) {
+	path := writeConfig(t, `
+[profile a]
+role_arn = arn:aws:iam::111122223333:role/A
+source_profile = b
+
+[profile b]
+role_arn = arn:aws:iam::111122223333:role/B
+source_profile = a
+`)
+	r := NewCredentialResolver(path)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.ProviderFor(context.Background(), "a")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("ProviderFor() error = nil, want a circular source_profile chain error")
+		}
+		if !strings.Contains(err.Error(), "circular source_profile chain") {
+			t.Errorf("ProviderFor() error = %v, want it to mention the circular chain", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ProviderFor() did not return; source_profile cycle was not detected")
+	}
+}
+
+func TestCredentialResolverDetectsSelfReferencingSourceProfile(t *testing.T) {
+	path := writeConfig(t, `
+[profile self]
+role_arn = arn:aws:iam::111122223333:role/Self
+source_profile = self
+`)
+	r := NewCredentialResolver(path)
+
+	_, err := r.ProviderFor(context.Background(), "self")
+	if err == nil || !strings.Contains(err.Error(), "circular source_profile chain") {
+		t.Fatalf("ProviderFor() error = %v, want a circular source_profile chain error", err)
+	}
+}
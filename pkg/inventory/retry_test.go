@@ -0,0 +1,35 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestIsThrottlingError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("boom"), false},
+		{"request limit exceeded", &smithy.GenericAPIError{Code: "RequestLimitExceeded"}, true},
+		{"throttling", &smithy.GenericAPIError{Code: "Throttling"}, true},
+		{"throttling exception", &smithy.GenericAPIError{Code: "ThrottlingException"}, true},
+		{"unrelated api error", &smithy.GenericAPIError{Code: "UnauthorizedOperation"}, false},
+		{"wrapped throttling", errAfterWrap(&smithy.GenericAPIError{Code: "Throttling"}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isThrottlingError(tt.err); got != tt.want {
+				t.Errorf("isThrottlingError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func errAfterWrap(err error) error {
+	return errors.Join(errors.New("context"), err)
+}
@@ -0,0 +1,57 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"golang.org/x/time/rate"
+)
+
+// TestRetryFetchDoesNotDuplicateAcrossRetries guards against a regression
+// where a throttle on a later page of a paginated fetch re-streamed
+// instances an earlier, already-successful page had already produced. Each
+// attempt's result must be discarded wholesale on failure, never merged
+// with a later attempt's.
+func TestRetryFetchDoesNotDuplicateAcrossRetries(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Inf, 1)
+
+	calls := 0
+	fetch := func(ctx context.Context) ([]Instance, error) {
+		calls++
+		if calls == 1 {
+			// Simulate a throttle after the first page was already
+			// buffered internally by this attempt.
+			return []Instance{{ID: "i-page1"}}, &smithy.GenericAPIError{Code: "Throttling"}
+		}
+		return []Instance{{ID: "i-page1"}, {ID: "i-page2"}}, nil
+	}
+
+	instances, err := retryFetch(context.Background(), limiter, "us-east-1", "default", fetch)
+	if err != nil {
+		t.Fatalf("retryFetch() error = %v", err)
+	}
+	if len(instances) != 2 {
+		t.Fatalf("retryFetch() returned %d instances, want 2 (no duplicates): %+v", len(instances), instances)
+	}
+	if calls != 2 {
+		t.Fatalf("fetch called %d times, want 2", calls)
+	}
+}
+
+func TestRetryFetchGivesUpOnNonThrottlingError(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Inf, 1)
+
+	calls := 0
+	fetch := func(ctx context.Context) ([]Instance, error) {
+		calls++
+		return nil, &smithy.GenericAPIError{Code: "UnauthorizedOperation"}
+	}
+
+	if _, err := retryFetch(context.Background(), limiter, "us-east-1", "default", fetch); err == nil {
+		t.Fatal("retryFetch() error = nil, want non-nil")
+	}
+	if calls != 1 {
+		t.Fatalf("fetch called %d times, want 1 (non-throttling errors should not retry)", calls)
+	}
+}
@@ -0,0 +1,10 @@
+package inventory
+
+import "context"
+
+// OutputSink consumes a completed Inventory. Implementations decide how
+// (and where) the result is rendered: a terminal table, a file, a
+// database, or a live metrics endpoint.
+type OutputSink interface {
+	Write(ctx context.Context, inv Inventory) error
+}
@@ -0,0 +1,52 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// JSONFileSink writes the full Inventory (accounts, regions, instances,
+// and any per-region errors) as a single indented JSON document.
+type JSONFileSink struct {
+	Path string
+}
+
+func (s JSONFileSink) Write(ctx context.Context, inv Inventory) error {
+	f, err := os.Create(s.Path)
+	if err != nil {
+		return fmt.Errorf("json sink: %w", err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(inv); err != nil {
+		return fmt.Errorf("json sink: %w", err)
+	}
+	return nil
+}
+
+// NDJSONSink writes one JSON object per instance, one per line, so the
+// output can be streamed into tools like jq or a log pipeline without
+// buffering the whole inventory.
+type NDJSONSink struct {
+	Path string
+}
+
+func (s NDJSONSink) Write(ctx context.Context, inv Inventory) error {
+	f, err := os.Create(s.Path)
+	if err != nil {
+		return fmt.Errorf("ndjson sink: %w", err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, in := range inv.Flatten() {
+		if err := enc.Encode(in); err != nil {
+			return fmt.Errorf("ndjson sink: %w", err)
+		}
+	}
+	return nil
+}
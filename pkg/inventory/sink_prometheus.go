@@ -0,0 +1,60 @@
+package inventory
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PrometheusSink exposes the most recently written Inventory as
+// ec2_running_instances{profile,region,type} gauges on Addr, so the tool
+// can run as a cron-scraped exporter instead of a one-shot CLI: each
+// Write call refreshes the gauges in place, starting the /metrics server
+// on the first call.
+type PrometheusSink struct {
+	Addr string
+
+	once     sync.Once
+	registry *prometheus.Registry
+	gauge    *prometheus.GaugeVec
+}
+
+func (s *PrometheusSink) init() {
+	s.registry = prometheus.NewRegistry()
+	s.gauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ec2_running_instances",
+		Help: "Number of running EC2 instances, labeled by profile, region, and instance type.",
+	}, []string{"profile", "region", "type"})
+	s.registry.MustRegister(s.gauge)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: s.Addr, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("prometheus sink: %v", err)
+		}
+	}()
+}
+
+func (s *PrometheusSink) Write(ctx context.Context, inv Inventory) error {
+	s.once.Do(s.init)
+
+	counts := make(map[[3]string]int)
+	for _, in := range inv.Flatten() {
+		if in.State != "running" {
+			continue
+		}
+		counts[[3]string{in.Profile, in.Region, in.Type}]++
+	}
+
+	s.gauge.Reset()
+	for key, count := range counts {
+		s.gauge.WithLabelValues(key[0], key[1], key[2]).Set(float64(count))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const createInstancesTable = `
+CREATE TABLE IF NOT EXISTS instances (
+	profile     TEXT NOT NULL,
+	region      TEXT NOT NULL,
+	id          TEXT NOT NULL,
+	name        TEXT,
+	state       TEXT,
+	type        TEXT,
+	private_ip  TEXT,
+	public_ip   TEXT,
+	vpc         TEXT,
+	az          TEXT,
+	launch_time TEXT,
+	PRIMARY KEY (profile, region, id)
+)`
+
+// SQLiteSink persists every instance in the Inventory into a local SQLite
+// database, replacing the table's contents each run so stale instances
+// don't linger between scans.
+type SQLiteSink struct {
+	Path string
+}
+
+func (s SQLiteSink) Write(ctx context.Context, inv Inventory) error {
+	db, err := sql.Open("sqlite3", s.Path)
+	if err != nil {
+		return fmt.Errorf("sqlite sink: %w", err)
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, createInstancesTable); err != nil {
+		return fmt.Errorf("sqlite sink: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite sink: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM instances"); err != nil {
+		return fmt.Errorf("sqlite sink: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO instances (profile, region, id, name, state, type, private_ip, public_ip, vpc, az, launch_time)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("sqlite sink: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, in := range inv.Flatten() {
+		if _, err := stmt.ExecContext(ctx,
+			in.Profile, in.Region, in.ID, in.Name, in.State, in.Type,
+			in.PrivateIP, in.PublicIP, in.VPC, in.AZ, in.LaunchTime.Format("2006-01-02T15:04:05Z07:00"),
+		); err != nil {
+			return fmt.Errorf("sqlite sink: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite sink: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package inventory
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// StdoutSink renders an Inventory to stdout as a table, CSV, or JSON,
+// optionally filtered to instances whose ID, Name, PrivateIP, or PublicIP
+// contains Filter. This is the default sink, modeled on the "list every
+// instance across every account, grep for what you need" workflow.
+type StdoutSink struct {
+	Format string // "table", "csv", or "json"
+	Filter string
+	Out    io.Writer // defaults to os.Stdout when nil
+}
+
+func (s StdoutSink) Write(ctx context.Context, inv Inventory) error {
+	out := s.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	instances := filterInstances(inv.Flatten(), s.Filter)
+
+	switch s.Format {
+	case "", "table":
+		return renderTable(out, instances)
+	case "csv":
+		return renderCSV(out, instances)
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(instances)
+	default:
+		return fmt.Errorf("unknown format %q (want table, csv, or json)", s.Format)
+	}
+}
+
+// filterInstances keeps only instances whose ID, Name, PrivateIP, or
+// PublicIP contains substr, so a user can grep for a specific IP or
+// instance-id across the whole org without piping through a shell filter.
+func filterInstances(instances []Instance, substr string) []Instance {
+	if substr == "" {
+		return instances
+	}
+	substr = strings.ToLower(substr)
+
+	filtered := make([]Instance, 0, len(instances))
+	for _, in := range instances {
+		if strings.Contains(strings.ToLower(in.ID), substr) ||
+			strings.Contains(strings.ToLower(in.Name), substr) ||
+			strings.Contains(strings.ToLower(in.PrivateIP), substr) ||
+			strings.Contains(strings.ToLower(in.PublicIP), substr) {
+			filtered = append(filtered, in)
+		}
+	}
+	return filtered
+}
+
+func renderTable(w io.Writer, instances []Instance) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROFILE REGION ID NAME STATE TYPE PRIVATE_IP PUBLIC_IP VPC AZ LAUNCH_TIME")
+	for _, in := range instances {
+		fmt.Fprintf(tw, "%s %s %s %s %s %s %s %s %s %s %s\n",
+			in.Profile, in.Region, in.ID, orDash(in.Name), in.State, in.Type,
+			orDash(in.PrivateIP), orDash(in.PublicIP), orDash(in.VPC), orDash(in.AZ),
+			in.LaunchTime.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
+
+func renderCSV(w io.Writer, instances []Instance) error {
+	cw := csv.NewWriter(w)
+	header := []string{"profile", "region", "id", "name", "state", "type", "private_ip", "public_ip", "vpc", "az", "launch_time"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, in := range instances {
+		row := []string{
+			in.Profile, in.Region, in.ID, in.Name, in.State, in.Type,
+			in.PrivateIP, in.PublicIP, in.VPC, in.AZ, in.LaunchTime.Format(time.RFC3339),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+package inventory
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFilterInstances(t *testing.T) {
+	instances := []Instance{
+		{ID: "i-abc123", Name: "web-1", PrivateIP: "10.0.0.5"},
+		{ID: "i-def456", Name: "db-1", PublicIP: "203.0.113.9"},
+	}
+
+	tests := []struct {
+		name   string
+		substr string
+		want   []string
+	}{
+		{"empty filter keeps all", "", []string{"i-abc123", "i-def456"}},
+		{"matches id case-insensitively", "ABC123", []string{"i-abc123"}},
+		{"matches name", "db", []string{"i-def456"}},
+		{"matches private ip", "10.0.0.5", []string{"i-abc123"}},
+		{"matches public ip", "203.0.113.9", []string{"i-def456"}},
+		{"no match", "nope", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterInstances(instances, tt.substr)
+			if len(got) != len(tt.want) {
+				t.Fatalf("filterInstances(%q) = %v, want ids %v", tt.substr, got, tt.want)
+			}
+			for i, in := range got {
+				if in.ID != tt.want[i] {
+					t.Errorf("filterInstances(%q)[%d].ID = %q, want %q", tt.substr, i, in.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRenderCSV(t *testing.T) {
+	instances := []Instance{{ID: "i-abc123", Profile: "prod", Region: "us-east-1"}}
+
+	var buf bytes.Buffer
+	if err := renderCSV(&buf, instances); err != nil {
+		t.Fatalf("renderCSV() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("renderCSV() wrote %d lines, want 2 (header + row)", len(lines))
+	}
+	if !strings.Contains(lines[1], "i-abc123") || !strings.Contains(lines[1], "prod") {
+		t.Errorf("renderCSV() row = %q, want it to contain the instance's id and profile", lines[1])
+	}
+}
+
+func TestOrDash(t *testing.T) {
+	if got := orDash(""); got != "-" {
+		t.Errorf("orDash(\"\") = %q, want \"-\"", got)
+	}
+	if got := orDash("x"); got != "x" {
+		t.Errorf("orDash(\"x\") = %q, want \"x\"", got)
+	}
+}
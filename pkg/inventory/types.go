@@ -0,0 +1,81 @@
+// Package inventory turns the (profile, region) instance-listing logic
+// that used to live in main into a reusable library: a Collector produces
+// a typed Inventory, and any number of OutputSink implementations can
+// consume it (stdout, files, a database, a metrics endpoint, ...).
+package inventory
+
+import (
+	"sort"
+	"time"
+)
+
+// Instance is a flattened, sortable view of an EC2 instance, tagged with
+// the profile/region it was discovered under.
+type Instance struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	PrivateIP  string    `json:"private_ip"`
+	PublicIP   string    `json:"public_ip"`
+	Type       string    `json:"type"`
+	State      string    `json:"state"`
+	VPC        string    `json:"vpc"`
+	AZ         string    `json:"az"`
+	LaunchTime time.Time `json:"launch_time"`
+	Profile    string    `json:"profile"`
+	Region     string    `json:"region"`
+}
+
+// RegionResult holds every instance found in a single region, or the error
+// that stopped the scan for that region.
+type RegionResult struct {
+	Region    string     `json:"region"`
+	Instances []Instance `json:"instances,omitempty"`
+	Err       error      `json:"-"`
+}
+
+// Account holds every RegionResult collected for a single profile.
+type Account struct {
+	Profile string         `json:"profile"`
+	Regions []RegionResult `json:"regions"`
+}
+
+// Inventory is the full result of a Collector run: every account, region,
+// and instance discovered, plus any per-region errors.
+type Inventory struct {
+	Accounts []Account `json:"accounts"`
+}
+
+// Flatten returns every instance across every account/region, sorted by
+// profile, then region, then name.
+func (inv Inventory) Flatten() []Instance {
+	var instances []Instance
+	for _, account := range inv.Accounts {
+		for _, region := range account.Regions {
+			instances = append(instances, region.Instances...)
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		a, b := instances[i], instances[j]
+		if a.Profile != b.Profile {
+			return a.Profile < b.Profile
+		}
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		return a.Name < b.Name
+	})
+	return instances
+}
+
+// Errors returns every per-region error recorded in the inventory.
+func (inv Inventory) Errors() []error {
+	var errs []error
+	for _, account := range inv.Accounts {
+		for _, region := range account.Regions {
+			if region.Err != nil {
+				errs = append(errs, region.Err)
+			}
+		}
+	}
+	return errs
+}
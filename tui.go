@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/adesokandijam/aws-sdk-concurrency/pkg/inventory"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+type cellKey struct {
+	profile string
+	region  string
+}
+
+type cellState struct {
+	status inventory.ProgressStatus
+	count  int
+	err    error
+}
+
+type tuiProgressMsg inventory.ProgressEvent
+
+type tuiDoneMsg struct {
+	err error
+}
+
+type tuiTickMsg time.Time
+
+// tuiModel renders a profiles x regions grid: each cell shows a spinner
+// while its DescribeInstances call is in flight, then flips to a running
+// count or an error indicator once it completes.
+type tuiModel struct {
+	profiles []string
+	regions  []string
+	cells    map[cellKey]cellState
+
+	progressCh <-chan inventory.ProgressEvent
+	doneCh     <-chan tuiDoneMsg
+
+	start        time.Time
+	spinnerFrame int
+	totalRunning int
+	done         bool
+	err          error
+}
+
+func newTUIModel(profiles []string, progressCh <-chan inventory.ProgressEvent, doneCh <-chan tuiDoneMsg) tuiModel {
+	return tuiModel{
+		profiles:   profiles,
+		cells:      make(map[cellKey]cellState),
+		progressCh: progressCh,
+		doneCh:     doneCh,
+		start:      time.Now(),
+	}
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return tea.Batch(waitForProgress(m.progressCh), waitForDone(m.doneCh), tickSpinner())
+}
+
+func waitForProgress(ch <-chan inventory.ProgressEvent) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return tuiProgressMsg(ev)
+	}
+}
+
+func waitForDone(ch <-chan tuiDoneMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+func tickSpinner() tea.Cmd {
+	return tea.Tick(120*time.Millisecond, func(t time.Time) tea.Msg { return tuiTickMsg(t) })
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "q" {
+			return m, tea.Quit
+		}
+
+	case tuiProgressMsg:
+		key := cellKey{profile: msg.Profile, region: msg.Region}
+		if _, seen := m.cells[key]; !seen {
+			m.regions = appendUnique(m.regions, msg.Region)
+		}
+
+		state := m.cells[key]
+		state.status = msg.Status
+		if msg.Status == inventory.ProgressDone {
+			state.count = msg.Count
+			state.err = msg.Err
+			if msg.Err == nil {
+				m.totalRunning += msg.Count
+			}
+		}
+		m.cells[key] = state
+		return m, waitForProgress(m.progressCh)
+
+	case tuiDoneMsg:
+		m.done = true
+		m.err = msg.err
+		return m, tea.Quit
+
+	case tuiTickMsg:
+		if m.done {
+			return m, nil
+		}
+		m.spinnerFrame++
+		return m, tickSpinner()
+	}
+	return m, nil
+}
+
+func appendUnique(regions []string, region string) []string {
+	for _, r := range regions {
+		if r == region {
+			return regions
+		}
+	}
+	return append(regions, region)
+}
+
+func (m tuiModel) View() string {
+	var b strings.Builder
+
+	regions := append([]string(nil), m.regions...)
+	sort.Strings(regions)
+
+	fmt.Fprintf(&b, "EC2 inventory scan — %d profile(s), %d region(s) seen so far\n\n", len(m.profiles), len(regions))
+
+	for _, profile := range m.profiles {
+		fmt.Fprintf(&b, "%-20s", profile)
+		for _, region := range regions {
+			fmt.Fprintf(&b, " %s", m.cellView(profile, region))
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "\nElapsed: %s   Running instances so far: %d\n", time.Since(m.start).Round(time.Second), m.totalRunning)
+	switch {
+	case m.done && m.err != nil:
+		fmt.Fprintf(&b, "Done, with errors: %v\n", m.err)
+	case m.done:
+		b.WriteString("Done.\n")
+	default:
+		b.WriteString("Press q to quit.\n")
+	}
+	return b.String()
+}
+
+func (m tuiModel) cellView(profile, region string) string {
+	state, ok := m.cells[cellKey{profile: profile, region: region}]
+	if !ok {
+		return "    ."
+	}
+	if state.status == inventory.ProgressDone {
+		if state.err != nil {
+			return "    !"
+		}
+		return fmt.Sprintf("%5d", state.count)
+	}
+	return "    " + spinnerFrames[m.spinnerFrame%len(spinnerFrames)]
+}
+
+// runTUI swaps the plain stdout output for a live dashboard: a
+// profiles x regions grid that fills in as the worker pool drains, with a
+// footer tracking elapsed time and the aggregate running-instance count.
+func runTUI(ctx context.Context, collector *inventory.Collector, profiles []string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	progressCh := make(chan inventory.ProgressEvent)
+	doneCh := make(chan tuiDoneMsg, 1)
+
+	go func() {
+		_, err := collector.CollectWithProgress(ctx, profiles, progressCh)
+		doneCh <- tuiDoneMsg{err: err}
+	}()
+
+	model := newTUIModel(profiles, progressCh, doneCh)
+	finalModel, err := tea.NewProgram(model).Run()
+	if err != nil {
+		return err
+	}
+
+	if final, ok := finalModel.(tuiModel); ok && final.err != nil {
+		return final.err
+	}
+	return nil
+}
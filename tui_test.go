@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestAppendUnique(t *testing.T) {
+	regions := appendUnique(nil, "us-east-1")
+	regions = appendUnique(regions, "us-west-2")
+	regions = appendUnique(regions, "us-east-1")
+
+	if got, want := len(regions), 2; got != want {
+		t.Fatalf("appendUnique() produced %d regions, want %d: %v", got, want, regions)
+	}
+	if regions[0] != "us-east-1" || regions[1] != "us-west-2" {
+		t.Errorf("appendUnique() = %v, want [us-east-1 us-west-2]", regions)
+	}
+}